@@ -0,0 +1,341 @@
+package context
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayouts are the HTML input layouts already supported by the
+// FormValueDate/FormValueTime/FormValueDateTime family, tried in order when
+// binding a time.Time struct field.
+var timeLayouts = []string{"2006-01-02", "15:04", "2006-01-02T15:04"}
+
+// bindSource identifies which part of the request BindForm/BindQuery/BindPath
+// reads values from.
+type bindSource int
+
+const (
+	bindForm bindSource = iota
+	bindQuery
+	bindPath
+)
+
+func (s bindSource) tagName() string {
+	switch s {
+	case bindQuery:
+		return "query"
+	case bindPath:
+		return "param"
+	default:
+		return "form"
+	}
+}
+
+// fieldBindError associates a bind or validation failure with the struct
+// field that caused it.
+type fieldBindError struct {
+	Field string
+	Err   error
+}
+
+// BindError aggregates every field that failed to bind or validate during a
+// BindForm/BindQuery/BindPath call, so callers can report all problems at
+// once instead of failing on the first one.
+type BindError struct {
+	Errors []fieldBindError
+}
+
+func (e *BindError) Error() string {
+	parts := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		parts[i] = fe.Field + ": " + fe.Err.Error()
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (e *BindError) add(field string, err error) {
+	e.Errors = append(e.Errors, fieldBindError{Field: field, Err: err})
+}
+
+// BindForm populates dst, a pointer to a struct, from the request's form
+// values using `form:"name"` tags. See BindQuery for the full tag syntax.
+func (c *Context) BindForm(dst interface{}) error {
+	return c.bind(bindForm, dst)
+}
+
+// BindQuery populates dst, a pointer to a struct, from the request's query
+// string using `query:"name"` tags.
+//
+// Tags support an inline default (`query:"page,default=1"`) or a separate
+// `default:"1"` tag, automatic conversion to int, int64, float64, bool,
+// time.Time (using the date/time/datetime-local layouts accepted by
+// FormValueDate and friends), []string/[]int for repeated keys, and
+// `base64:"true"` to decode the value of a string field (other field types
+// ignore it). A `validate:"required,min=1"`
+// tag runs the built-in required/min/max/len checks. Nested structs and
+// embedded types are walked recursively. Every failing field is collected
+// into a single *BindError rather than returning on the first error.
+func (c *Context) BindQuery(dst interface{}) error {
+	return c.bind(bindQuery, dst)
+}
+
+// BindPath populates dst, a pointer to a struct, from the request's path
+// parameters using `param:"name"` tags. See BindQuery for the full tag
+// syntax.
+func (c *Context) BindPath(dst interface{}) error {
+	return c.bind(bindPath, dst)
+}
+
+func (c *Context) bind(src bindSource, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("context: bind destination must be a non-nil pointer to a struct")
+	}
+
+	errs := &BindError{}
+	c.bindStruct(src, rv.Elem(), errs)
+	if len(errs.Errors) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (c *Context) bindStruct(src bindSource, rv reflect.Value, errs *BindError) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fv := rv.Field(i)
+
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			c.bindStruct(src, fv, errs)
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup(src.tagName())
+		if !ok {
+			if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{}) {
+				c.bindStruct(src, fv, errs)
+			}
+			continue
+		}
+
+		key, def, hasDefault := parseBindTag(tag)
+		if !hasDefault {
+			if d, ok := field.Tag.Lookup("default"); ok {
+				def, hasDefault = d, true
+			}
+		}
+		if key == "" {
+			continue
+		}
+
+		raw, found := c.valuesFor(src, key)
+		if !found && hasDefault {
+			raw, found = []string{def}, true
+		}
+
+		if rules := field.Tag.Get("validate"); rules != "" {
+			if err := validateBindField(rules, raw, found, fv); err != nil {
+				errs.add(field.Name, err)
+				continue
+			}
+		}
+
+		if !found {
+			continue
+		}
+
+		if err := setBindField(fv, raw, field.Tag.Get("base64") == "true"); err != nil {
+			errs.add(field.Name, err)
+		}
+	}
+}
+
+// valuesFor returns the raw values for key from the request part identified
+// by src, and whether the key was present at all.
+func (c *Context) valuesFor(src bindSource, key string) ([]string, bool) {
+	switch src {
+	case bindQuery:
+		if values := c.QueryValues(key); len(values) > 0 {
+			return values, true
+		}
+		if v := c.QueryParam(key); v != "" {
+			return []string{v}, true
+		}
+	case bindPath:
+		if v := c.Param(key); v != "" {
+			return []string{v}, true
+		}
+	default:
+		if values := c.FormValues(key); len(values) > 0 {
+			return values, true
+		}
+		if v := c.FormValue(key); v != "" {
+			return []string{v}, true
+		}
+	}
+	return nil, false
+}
+
+// parseBindTag splits a `form:"name,default=1"`-style tag into its key and
+// optional inline default.
+func parseBindTag(tag string) (key string, def string, hasDefault bool) {
+	parts := strings.Split(tag, ",")
+	key = strings.TrimSpace(parts[0])
+	for _, opt := range parts[1:] {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(opt), "default="); ok {
+			def, hasDefault = v, true
+		}
+	}
+	return key, def, hasDefault
+}
+
+func setBindField(fv reflect.Value, raw []string, decodeBase64 bool) error {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		v := strings.TrimSpace(raw[0])
+		if decodeBase64 {
+			if de, err := base64.URLEncoding.DecodeString(v); err == nil {
+				v = string(de)
+			}
+		}
+		fv.SetString(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(raw[0]), 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(strings.TrimSpace(raw[0]), 64)
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw[0]))
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Struct:
+		if fv.Type() != reflect.TypeOf(time.Time{}) {
+			return fmt.Errorf("unsupported field type %s", fv.Type())
+		}
+		t, err := parseBindTime(strings.TrimSpace(raw[0]))
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+	case reflect.Slice:
+		return setBindSlice(fv, raw)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return nil
+}
+
+func setBindSlice(fv reflect.Value, raw []string) error {
+	switch fv.Type().Elem().Kind() {
+	case reflect.String:
+		fv.Set(reflect.ValueOf(append([]string(nil), raw...)))
+	case reflect.Int:
+		out := make([]int, len(raw))
+		for i, v := range raw {
+			n, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil {
+				return &ValuesParseError{Index: i, Err: err}
+			}
+			out[i] = n
+		}
+		fv.Set(reflect.ValueOf(out))
+	default:
+		return fmt.Errorf("unsupported slice element type %s", fv.Type().Elem())
+	}
+	return nil
+}
+
+func parseBindTime(v string) (time.Time, error) {
+	var lastErr error
+	for _, layout := range timeLayouts {
+		t, err := time.Parse(layout, v)
+		if err == nil {
+			return t, nil
+		}
+		lastErr = err
+	}
+	return time.Time{}, lastErr
+}
+
+// validateBindField runs the built-in required/min/max/len rules from a
+// `validate:"..."` tag against the raw value(s) about to be bound into fv.
+func validateBindField(rules string, raw []string, found bool, fv reflect.Value) error {
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		name, arg, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "required":
+			if !found || len(raw) == 0 || raw[0] == "" {
+				return errors.New("is required")
+			}
+		case "min", "max":
+			if !found || len(raw) == 0 {
+				continue
+			}
+			limit, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return err
+			}
+			value := bindMagnitude(fv, raw[0])
+			if name == "min" && value < limit {
+				return fmt.Errorf("must be at least %s", arg)
+			}
+			if name == "max" && value > limit {
+				return fmt.Errorf("must be at most %s", arg)
+			}
+		case "len":
+			if !found || len(raw) == 0 {
+				continue
+			}
+			n, err := strconv.Atoi(arg)
+			if err != nil {
+				return err
+			}
+			if len(raw[0]) != n {
+				return fmt.Errorf("must have length %s", arg)
+			}
+		}
+	}
+	return nil
+}
+
+// bindMagnitude returns the numeric value of raw when fv binds to a numeric
+// field, or its string length otherwise, for use by the min/max validators.
+func bindMagnitude(fv reflect.Value, raw string) float64 {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Float32, reflect.Float64:
+		if n, err := strconv.ParseFloat(strings.TrimSpace(raw), 64); err == nil {
+			return n
+		}
+	}
+	return float64(len(raw))
+}
@@ -2,6 +2,10 @@ package context
 
 import (
 	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	neturl "net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -17,18 +21,104 @@ type Context struct {
 
 var _ echo.Context = &Context{}
 
+// ValuesParseError reports a failure to parse one element of a multi-value
+// form or query field, identifying the offending index so callers can build
+// useful validation messages.
+type ValuesParseError struct {
+	Index int
+	Err   error
+}
+
+func (e *ValuesParseError) Error() string {
+	return fmt.Sprintf("element %d: %v", e.Index, e.Err)
+}
+
+func (e *ValuesParseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrRedirectHostNotAllowed is returned by RedirectHTML/RedirectHTMLAfter
+// when url is absolute and its host was not registered with
+// RegisterRedirectAllowlist.
+var ErrRedirectHostNotAllowed = echo.NewHTTPError(400, "redirect host not allowed")
+
+// redirectAllowlist holds the hosts registered with RegisterRedirectAllowlist.
+// An empty allowlist disables the check, preserving prior behavior for
+// applications that never opt in.
+var redirectAllowlist = map[string]bool{}
+
+// RegisterRedirectAllowlist registers hosts that RedirectHTML/RedirectHTMLAfter
+// are allowed to send an absolute URL redirect to. Once at least one host is
+// registered, an absolute url whose host isn't on the list is rejected with
+// ErrRedirectHostNotAllowed instead of being rendered, closing the open-redirect
+// hole where url comes from untrusted input (e.g. a login return URL).
+func RegisterRedirectAllowlist(hosts ...string) {
+	for _, host := range hosts {
+		redirectAllowlist[strings.ToLower(host)] = true
+	}
+}
+
+func redirectHostAllowed(rawURL string) bool {
+	if len(redirectAllowlist) == 0 {
+		return true
+	}
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	if u.Host == "" {
+		return true
+	}
+	return redirectAllowlist[strings.ToLower(u.Host)]
+}
+
 // RedirectHTML redirects the request to a provided URL with status code.
 // By this order
 // 1.HTML redirect
 // 2.JavaScript redirect
 func (c *Context) RedirectHTML(code int, url string) error {
+	return c.redirectHTML(code, url, 0)
+}
+
+// RedirectHTMLAfter is RedirectHTML with the redirect delayed by delay: the
+// meta refresh's content is set to the equivalent number of seconds and the
+// JavaScript fallback fires via setTimeout instead of redirecting immediately.
+func (c *Context) RedirectHTMLAfter(code int, url string, delay time.Duration) error {
+	return c.redirectHTML(code, url, delay)
+}
+
+func (c *Context) redirectHTML(code int, url string, delay time.Duration) error {
 	if code < 300 || code > 308 {
 		return echo.ErrInvalidRedirectCode
 	}
+	if !redirectHostAllowed(url) {
+		return ErrRedirectHostNotAllowed
+	}
+
+	jsURL, err := json.Marshal(url)
+	if err != nil {
+		return err
+	}
+	attrURL := template.HTMLEscapeString(url)
+
+	script := fmt.Sprintf("window.location.replace(%s);", jsURL)
+	if delay > 0 {
+		script = fmt.Sprintf("setTimeout(function(){window.location.replace(%s);}, %d);", jsURL, delay.Milliseconds())
+	}
+
+	// The meta refresh only understands whole seconds, so a sub-second delay
+	// is rounded up rather than truncated to 0 - otherwise the HTML fallback
+	// would redirect immediately while the JS setTimeout still waits.
+	metaSeconds := 0
+	if delay > 0 {
+		metaSeconds = int((delay + time.Second - 1) / time.Second)
+	}
+
+	html := "<html><head><meta http-equiv='Refresh' content='" + strconv.Itoa(metaSeconds) + "; URL=" + attrURL + "'></head><body><script>" + script + "</script></body></html>"
+
 	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
 	c.Response().WriteHeader(code)
-	html := "<html><head><meta http-equiv='Refresh' content='0; URL=" + url + "'></head><body><script>window.location.replace('" + url + "');</script></body></html>"
-	_, err := c.Response().Write([]byte(html))
+	_, err = c.Response().Write([]byte(html))
 	return err
 }
 
@@ -269,3 +359,390 @@ func (c *Context) ParamBool(name string) (bool, error) {
 
 	return strconv.ParseBool(v)
 }
+
+// QueryDefault returns the query string value for the provided name.
+//
+// Returns the "def" if not found.
+func (c *Context) QueryDefault(name string, def string) string {
+	if v := c.QueryParam(name); len(v) > 0 {
+		return v
+	}
+	return def
+}
+
+// QueryTrim returns the query string value for the provided name, without trailing spaces.
+func (c *Context) QueryTrim(name string) string {
+	return strings.TrimSpace(c.QueryParam(name))
+}
+
+// QueryDate returns the query string date value for the provided name.
+// https://developer.mozilla.org/en-US/docs/Web/HTML/Element/input/date
+func (c *Context) QueryDate(name string) time.Time {
+	out, err := time.Parse("2006-01-02", strings.TrimSpace(c.QueryParam(name)))
+	if err != nil {
+		out = time.Time{}
+	}
+	return out
+}
+
+// QueryTime returns the query string time value for the provided name.
+// https://developer.mozilla.org/en-US/docs/Web/HTML/Element/input/time
+func (c *Context) QueryTime(name string) time.Time {
+	out, err := time.Parse("15:04", strings.TrimSpace(c.QueryParam(name)))
+	if err != nil {
+		out = time.Time{}
+	}
+	return out
+}
+
+// QueryDateTime returns the query string datetime-local value for the provided name.
+// https://developer.mozilla.org/en-US/docs/Web/HTML/Element/input/datetime-local
+func (c *Context) QueryDateTime(name string) time.Time {
+	out, err := time.Parse("2006-01-02T15:04", strings.TrimSpace(c.QueryParam(name)))
+	if err != nil {
+		out = time.Time{}
+	}
+	return out
+}
+
+// QueryBase64 returns the query string value for the provided name.
+//
+// If value encoded with base64 return will be decoded string.
+func (c *Context) QueryBase64(name string) string {
+	v := c.QueryTrim(name)
+	if de, err := base64.URLEncoding.DecodeString(v); err == nil {
+		v = string(de)
+	}
+	return v
+}
+
+// QueryInt returns the query string value for the provided name, as int.
+//
+// If not found returns -1 and a non-nil error.
+func (c *Context) QueryInt(name string) (int, error) {
+	v := c.QueryTrim(name)
+	if v == "" {
+		return -1, echo.ErrNotFound
+	}
+	return strconv.Atoi(v)
+}
+
+// QueryIntDefault returns the query string value for the provided name, as int.
+//
+// If not found returns or parse errors the "def".
+func (c *Context) QueryIntDefault(name string, def int) int {
+	if v, err := c.QueryInt(name); err == nil {
+		return v
+	}
+
+	return def
+}
+
+// QueryInt64 returns the query string value for the provided name, as int64.
+//
+// If not found returns -1 and a no-nil error.
+func (c *Context) QueryInt64(name string) (int64, error) {
+	v := c.QueryTrim(name)
+	if v == "" {
+		return -1, echo.ErrNotFound
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+// QueryInt64Default returns the query string value for the provided name, as int64.
+//
+// If not found or parse errors returns the "def".
+func (c *Context) QueryInt64Default(name string, def int64) int64 {
+	if v, err := c.QueryInt64(name); err == nil {
+		return v
+	}
+
+	return def
+}
+
+// QueryFloat64 returns the query string value for the provided name, as float64.
+//
+// If not found returns -1 and a non-nil error.
+func (c *Context) QueryFloat64(name string) (float64, error) {
+	v := c.QueryTrim(name)
+	if v == "" {
+		return -1, echo.ErrNotFound
+	}
+	return strconv.ParseFloat(v, 64)
+}
+
+// QueryFloat64Default returns the query string value for the provided name, as float64.
+//
+// If not found or parse errors returns the "def".
+func (c *Context) QueryFloat64Default(name string, def float64) float64 {
+	if v, err := c.QueryFloat64(name); err == nil {
+		return v
+	}
+
+	return def
+}
+
+// QueryBool returns the query string value for the provided name, as bool.
+//
+// If not found returns false and echo.ErrNotFound; if the value does not
+// parse as a bool, returns false and the parse error.
+func (c *Context) QueryBool(name string) (bool, error) {
+	v := c.QueryTrim(name)
+	if v == "" {
+		return false, echo.ErrNotFound
+	}
+
+	return strconv.ParseBool(v)
+}
+
+// FormValues returns all form field values for the provided name, in the
+// order they were submitted. Useful for repeated keys such as checkbox
+// groups.
+func (c *Context) FormValues(name string) []string {
+	params, err := c.FormParams()
+	if err != nil {
+		return nil
+	}
+	return params[name]
+}
+
+// FormValuesTrim returns all form field values for the provided name,
+// without trailing spaces.
+func (c *Context) FormValuesTrim(name string) []string {
+	values := c.FormValues(name)
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = strings.TrimSpace(v)
+	}
+	return out
+}
+
+// FormValuesInt returns all form field values for the provided name, as []int.
+//
+// If not found returns nil and echo.ErrNotFound. If an element fails to
+// parse, returns nil and a *ValuesParseError identifying the offending index.
+func (c *Context) FormValuesInt(name string) ([]int, error) {
+	values := c.FormValuesTrim(name)
+	if len(values) == 0 {
+		return nil, echo.ErrNotFound
+	}
+
+	out := make([]int, len(values))
+	for i, v := range values {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, &ValuesParseError{Index: i, Err: err}
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+// FormValuesInt64 returns all form field values for the provided name, as []int64.
+//
+// If not found returns nil and echo.ErrNotFound. If an element fails to
+// parse, returns nil and a *ValuesParseError identifying the offending index.
+func (c *Context) FormValuesInt64(name string) ([]int64, error) {
+	values := c.FormValuesTrim(name)
+	if len(values) == 0 {
+		return nil, echo.ErrNotFound
+	}
+
+	out := make([]int64, len(values))
+	for i, v := range values {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, &ValuesParseError{Index: i, Err: err}
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+// FormValuesFloat64 returns all form field values for the provided name, as []float64.
+//
+// If not found returns nil and echo.ErrNotFound. If an element fails to
+// parse, returns nil and a *ValuesParseError identifying the offending index.
+func (c *Context) FormValuesFloat64(name string) ([]float64, error) {
+	values := c.FormValuesTrim(name)
+	if len(values) == 0 {
+		return nil, echo.ErrNotFound
+	}
+
+	out := make([]float64, len(values))
+	for i, v := range values {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, &ValuesParseError{Index: i, Err: err}
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+// FormValuesBool returns all form field values for the provided name, as []bool.
+//
+// If not found returns nil and echo.ErrNotFound. If an element fails to
+// parse, returns nil and a *ValuesParseError identifying the offending index.
+func (c *Context) FormValuesBool(name string) ([]bool, error) {
+	values := c.FormValuesTrim(name)
+	if len(values) == 0 {
+		return nil, echo.ErrNotFound
+	}
+
+	out := make([]bool, len(values))
+	for i, v := range values {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, &ValuesParseError{Index: i, Err: err}
+		}
+		out[i] = b
+	}
+	return out, nil
+}
+
+// FormValuesDate returns all form field date values for the provided name.
+// https://developer.mozilla.org/en-US/docs/Web/HTML/Element/input/date
+//
+// If not found returns nil and echo.ErrNotFound. If an element fails to
+// parse, returns nil and a *ValuesParseError identifying the offending index.
+func (c *Context) FormValuesDate(name string) ([]time.Time, error) {
+	values := c.FormValuesTrim(name)
+	if len(values) == 0 {
+		return nil, echo.ErrNotFound
+	}
+
+	out := make([]time.Time, len(values))
+	for i, v := range values {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, &ValuesParseError{Index: i, Err: err}
+		}
+		out[i] = t
+	}
+	return out, nil
+}
+
+// QueryValues returns all query string values for the provided name, in the
+// order they appear in the URL. Useful for repeated keys such as
+// `friend=Jess&friend=Sarah`.
+func (c *Context) QueryValues(name string) []string {
+	return c.QueryParams()[name]
+}
+
+// QueryValuesTrim returns all query string values for the provided name,
+// without trailing spaces.
+func (c *Context) QueryValuesTrim(name string) []string {
+	values := c.QueryValues(name)
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = strings.TrimSpace(v)
+	}
+	return out
+}
+
+// QueryValuesInt returns all query string values for the provided name, as []int.
+//
+// If not found returns nil and echo.ErrNotFound. If an element fails to
+// parse, returns nil and a *ValuesParseError identifying the offending index.
+func (c *Context) QueryValuesInt(name string) ([]int, error) {
+	values := c.QueryValuesTrim(name)
+	if len(values) == 0 {
+		return nil, echo.ErrNotFound
+	}
+
+	out := make([]int, len(values))
+	for i, v := range values {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, &ValuesParseError{Index: i, Err: err}
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+// QueryValuesInt64 returns all query string values for the provided name, as []int64.
+//
+// If not found returns nil and echo.ErrNotFound. If an element fails to
+// parse, returns nil and a *ValuesParseError identifying the offending index.
+func (c *Context) QueryValuesInt64(name string) ([]int64, error) {
+	values := c.QueryValuesTrim(name)
+	if len(values) == 0 {
+		return nil, echo.ErrNotFound
+	}
+
+	out := make([]int64, len(values))
+	for i, v := range values {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, &ValuesParseError{Index: i, Err: err}
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+// QueryValuesFloat64 returns all query string values for the provided name, as []float64.
+//
+// If not found returns nil and echo.ErrNotFound. If an element fails to
+// parse, returns nil and a *ValuesParseError identifying the offending index.
+func (c *Context) QueryValuesFloat64(name string) ([]float64, error) {
+	values := c.QueryValuesTrim(name)
+	if len(values) == 0 {
+		return nil, echo.ErrNotFound
+	}
+
+	out := make([]float64, len(values))
+	for i, v := range values {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, &ValuesParseError{Index: i, Err: err}
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+// QueryValuesBool returns all query string values for the provided name, as []bool.
+//
+// If not found returns nil and echo.ErrNotFound. If an element fails to
+// parse, returns nil and a *ValuesParseError identifying the offending index.
+func (c *Context) QueryValuesBool(name string) ([]bool, error) {
+	values := c.QueryValuesTrim(name)
+	if len(values) == 0 {
+		return nil, echo.ErrNotFound
+	}
+
+	out := make([]bool, len(values))
+	for i, v := range values {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, &ValuesParseError{Index: i, Err: err}
+		}
+		out[i] = b
+	}
+	return out, nil
+}
+
+// QueryValuesDate returns all query string date values for the provided name.
+// https://developer.mozilla.org/en-US/docs/Web/HTML/Element/input/date
+//
+// If not found returns nil and echo.ErrNotFound. If an element fails to
+// parse, returns nil and a *ValuesParseError identifying the offending index.
+func (c *Context) QueryValuesDate(name string) ([]time.Time, error) {
+	values := c.QueryValuesTrim(name)
+	if len(values) == 0 {
+		return nil, echo.ErrNotFound
+	}
+
+	out := make([]time.Time, len(values))
+	for i, v := range values {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, &ValuesParseError{Index: i, Err: err}
+		}
+		out[i] = t
+	}
+	return out, nil
+}
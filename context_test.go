@@ -0,0 +1,30 @@
+package context
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestRedirectHTMLAfterRoundsSubSecondMetaDelayUp(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := &Context{Context: e.NewContext(req, rec)}
+
+	if err := c.RedirectHTMLAfter(302, "/next", 500*time.Millisecond); err != nil {
+		t.Fatalf("RedirectHTMLAfter returned error: %v", err)
+	}
+
+	body := rec.Body.String()
+	if !strings.Contains(body, "content='1; URL=/next'") {
+		t.Fatalf("expected meta refresh to round up to 1 second, got: %s", body)
+	}
+	if !strings.Contains(body, "}, 500);") {
+		t.Fatalf("expected setTimeout to keep the millisecond delay, got: %s", body)
+	}
+}
@@ -0,0 +1,382 @@
+package context
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ErrCookieTampered is returned by CookieSigned when a cookie's value does
+// not match the HMAC signature it was written with.
+var ErrCookieTampered = errors.New("context: cookie value failed signature verification")
+
+// cookieSecret is the package-level key used to sign and verify cookies set
+// with SetCookieSigned/CookieSigned. Set it once at startup with
+// SetCookieSecret.
+var cookieSecret []byte
+
+// SetCookieSecret sets the HMAC key used by SetCookieSigned and
+// CookieSigned. It must be called before either is used.
+func SetCookieSecret(secret []byte) {
+	cookieSecret = secret
+}
+
+// CookieOptions controls the attributes of a cookie written by
+// SetCookieSigned.
+type CookieOptions struct {
+	Path     string
+	Domain   string
+	MaxAge   int
+	Secure   bool
+	HTTPOnly bool
+	SameSite http.SameSite
+}
+
+// SetCookieSigned sets a cookie whose value is HMAC-signed with the secret
+// configured via SetCookieSecret, so that it can later be read back with
+// CookieSigned and verified to be untampered.
+func (c *Context) SetCookieSigned(name, value string, opts CookieOptions) error {
+	if len(cookieSecret) == 0 {
+		return errors.New("context: cookie secret not set, call SetCookieSecret first")
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     name,
+		Value:    signCookieValue(value),
+		Path:     opts.Path,
+		Domain:   opts.Domain,
+		MaxAge:   opts.MaxAge,
+		Secure:   opts.Secure,
+		HttpOnly: opts.HTTPOnly,
+		SameSite: opts.SameSite,
+	})
+	return nil
+}
+
+// CookieSigned returns the verified payload of a cookie written by
+// SetCookieSigned, or ErrCookieTampered if the signature does not match.
+func (c *Context) CookieSigned(name string) (string, error) {
+	cookie, err := c.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+	return verifyCookieValue(cookie.Value)
+}
+
+func signCookieValue(value string) string {
+	mac := hmac.New(sha256.New, cookieSecret)
+	mac.Write([]byte(value))
+	return base64.URLEncoding.EncodeToString([]byte(value)) + "." + base64.URLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func verifyCookieValue(signed string) (string, error) {
+	encValue, encSig, ok := strings.Cut(signed, ".")
+	if !ok {
+		return "", ErrCookieTampered
+	}
+
+	value, err := base64.URLEncoding.DecodeString(encValue)
+	if err != nil {
+		return "", ErrCookieTampered
+	}
+	sig, err := base64.URLEncoding.DecodeString(encSig)
+	if err != nil {
+		return "", ErrCookieTampered
+	}
+
+	mac := hmac.New(sha256.New, cookieSecret)
+	mac.Write(value)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return "", ErrCookieTampered
+	}
+	return string(value), nil
+}
+
+func (c *Context) cookieValue(name string) string {
+	cookie, err := c.Cookie(name)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// CookieDefault returns the named cookie's value.
+//
+// Returns the "def" if not found.
+func (c *Context) CookieDefault(name string, def string) string {
+	if v := c.cookieValue(name); len(v) > 0 {
+		return v
+	}
+	return def
+}
+
+// CookieTrim returns the named cookie's value, without trailing spaces.
+func (c *Context) CookieTrim(name string) string {
+	return strings.TrimSpace(c.cookieValue(name))
+}
+
+// CookieDate returns the named cookie's date value.
+// https://developer.mozilla.org/en-US/docs/Web/HTML/Element/input/date
+func (c *Context) CookieDate(name string) time.Time {
+	out, err := time.Parse("2006-01-02", c.CookieTrim(name))
+	if err != nil {
+		out = time.Time{}
+	}
+	return out
+}
+
+// CookieTime returns the named cookie's time value.
+// https://developer.mozilla.org/en-US/docs/Web/HTML/Element/input/time
+func (c *Context) CookieTime(name string) time.Time {
+	out, err := time.Parse("15:04", c.CookieTrim(name))
+	if err != nil {
+		out = time.Time{}
+	}
+	return out
+}
+
+// CookieDateTime returns the named cookie's datetime-local value.
+// https://developer.mozilla.org/en-US/docs/Web/HTML/Element/input/datetime-local
+func (c *Context) CookieDateTime(name string) time.Time {
+	out, err := time.Parse("2006-01-02T15:04", c.CookieTrim(name))
+	if err != nil {
+		out = time.Time{}
+	}
+	return out
+}
+
+// CookieBase64 returns the named cookie's value.
+//
+// If value encoded with base64 return will be decoded string.
+func (c *Context) CookieBase64(name string) string {
+	v := c.CookieTrim(name)
+	if de, err := base64.URLEncoding.DecodeString(v); err == nil {
+		v = string(de)
+	}
+	return v
+}
+
+// CookieInt returns the named cookie's value, as int.
+//
+// If not found returns -1 and a non-nil error.
+func (c *Context) CookieInt(name string) (int, error) {
+	v := c.CookieTrim(name)
+	if v == "" {
+		return -1, echo.ErrNotFound
+	}
+	return strconv.Atoi(v)
+}
+
+// CookieIntDefault returns the named cookie's value, as int.
+//
+// If not found returns or parse errors the "def".
+func (c *Context) CookieIntDefault(name string, def int) int {
+	if v, err := c.CookieInt(name); err == nil {
+		return v
+	}
+
+	return def
+}
+
+// CookieInt64 returns the named cookie's value, as int64.
+//
+// If not found returns -1 and a non-nil error.
+func (c *Context) CookieInt64(name string) (int64, error) {
+	v := c.CookieTrim(name)
+	if v == "" {
+		return -1, echo.ErrNotFound
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+// CookieInt64Default returns the named cookie's value, as int64.
+//
+// If not found or parse errors returns the "def".
+func (c *Context) CookieInt64Default(name string, def int64) int64 {
+	if v, err := c.CookieInt64(name); err == nil {
+		return v
+	}
+
+	return def
+}
+
+// CookieFloat64 returns the named cookie's value, as float64.
+//
+// If not found returns -1 and a non-nil error.
+func (c *Context) CookieFloat64(name string) (float64, error) {
+	v := c.CookieTrim(name)
+	if v == "" {
+		return -1, echo.ErrNotFound
+	}
+	return strconv.ParseFloat(v, 64)
+}
+
+// CookieFloat64Default returns the named cookie's value, as float64.
+//
+// If not found or parse errors returns the "def".
+func (c *Context) CookieFloat64Default(name string, def float64) float64 {
+	if v, err := c.CookieFloat64(name); err == nil {
+		return v
+	}
+
+	return def
+}
+
+// CookieBool returns the named cookie's value, as bool.
+//
+// If not found returns false and echo.ErrNotFound; if the value does not
+// parse as a bool, returns false and the parse error.
+func (c *Context) CookieBool(name string) (bool, error) {
+	v := c.CookieTrim(name)
+	if v == "" {
+		return false, echo.ErrNotFound
+	}
+
+	return strconv.ParseBool(v)
+}
+
+func (c *Context) headerValue(name string) string {
+	return c.Request().Header.Get(name)
+}
+
+// HeaderDefault returns the request header value for the provided name.
+//
+// Returns the "def" if not found.
+func (c *Context) HeaderDefault(name string, def string) string {
+	if v := c.headerValue(name); len(v) > 0 {
+		return v
+	}
+	return def
+}
+
+// HeaderTrim returns the request header value for the provided name, without trailing spaces.
+func (c *Context) HeaderTrim(name string) string {
+	return strings.TrimSpace(c.headerValue(name))
+}
+
+// HeaderDate returns the request header date value for the provided name.
+// https://developer.mozilla.org/en-US/docs/Web/HTML/Element/input/date
+func (c *Context) HeaderDate(name string) time.Time {
+	out, err := time.Parse("2006-01-02", c.HeaderTrim(name))
+	if err != nil {
+		out = time.Time{}
+	}
+	return out
+}
+
+// HeaderTime returns the request header time value for the provided name.
+// https://developer.mozilla.org/en-US/docs/Web/HTML/Element/input/time
+func (c *Context) HeaderTime(name string) time.Time {
+	out, err := time.Parse("15:04", c.HeaderTrim(name))
+	if err != nil {
+		out = time.Time{}
+	}
+	return out
+}
+
+// HeaderDateTime returns the request header datetime-local value for the provided name.
+// https://developer.mozilla.org/en-US/docs/Web/HTML/Element/input/datetime-local
+func (c *Context) HeaderDateTime(name string) time.Time {
+	out, err := time.Parse("2006-01-02T15:04", c.HeaderTrim(name))
+	if err != nil {
+		out = time.Time{}
+	}
+	return out
+}
+
+// HeaderBase64 returns the request header value for the provided name.
+//
+// If value encoded with base64 return will be decoded string.
+func (c *Context) HeaderBase64(name string) string {
+	v := c.HeaderTrim(name)
+	if de, err := base64.URLEncoding.DecodeString(v); err == nil {
+		v = string(de)
+	}
+	return v
+}
+
+// HeaderInt returns the request header value for the provided name, as int.
+//
+// If not found returns -1 and a non-nil error.
+func (c *Context) HeaderInt(name string) (int, error) {
+	v := c.HeaderTrim(name)
+	if v == "" {
+		return -1, echo.ErrNotFound
+	}
+	return strconv.Atoi(v)
+}
+
+// HeaderIntDefault returns the request header value for the provided name, as int.
+//
+// If not found returns or parse errors the "def".
+func (c *Context) HeaderIntDefault(name string, def int) int {
+	if v, err := c.HeaderInt(name); err == nil {
+		return v
+	}
+
+	return def
+}
+
+// HeaderInt64 returns the request header value for the provided name, as int64.
+//
+// If not found returns -1 and a non-nil error.
+func (c *Context) HeaderInt64(name string) (int64, error) {
+	v := c.HeaderTrim(name)
+	if v == "" {
+		return -1, echo.ErrNotFound
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+// HeaderInt64Default returns the request header value for the provided name, as int64.
+//
+// If not found or parse errors returns the "def".
+func (c *Context) HeaderInt64Default(name string, def int64) int64 {
+	if v, err := c.HeaderInt64(name); err == nil {
+		return v
+	}
+
+	return def
+}
+
+// HeaderFloat64 returns the request header value for the provided name, as float64.
+//
+// If not found returns -1 and a non-nil error.
+func (c *Context) HeaderFloat64(name string) (float64, error) {
+	v := c.HeaderTrim(name)
+	if v == "" {
+		return -1, echo.ErrNotFound
+	}
+	return strconv.ParseFloat(v, 64)
+}
+
+// HeaderFloat64Default returns the request header value for the provided name, as float64.
+//
+// If not found or parse errors returns the "def".
+func (c *Context) HeaderFloat64Default(name string, def float64) float64 {
+	if v, err := c.HeaderFloat64(name); err == nil {
+		return v
+	}
+
+	return def
+}
+
+// HeaderBool returns the request header value for the provided name, as bool.
+//
+// If not found returns false and echo.ErrNotFound; if the value does not
+// parse as a bool, returns false and the parse error.
+func (c *Context) HeaderBool(name string) (bool, error) {
+	v := c.HeaderTrim(name)
+	if v == "" {
+		return false, echo.ErrNotFound
+	}
+
+	return strconv.ParseBool(v)
+}
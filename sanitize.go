@@ -0,0 +1,194 @@
+package context
+
+// This file imports golang.org/x/net/html and golang.org/x/net/html/atom to
+// tokenize and re-serialize HTML for the default Sanitizer. That's the
+// tradeoff for not requiring bluemonday: x/net/html is the same tokenizer
+// bluemonday itself builds on. This tree has no go.mod yet; whoever adds one
+// must record x/net as a direct requirement rather than leaving it to be
+// picked up transitively through echo/gommon.
+import (
+	"html/template"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// HTMLSanitizer strips dangerous markup from untrusted HTML while preserving
+// a safe subset of formatting tags.
+type HTMLSanitizer interface {
+	Sanitize(s string) string
+}
+
+// Sanitizer is the policy used by FormValueSafeHTML and its Param/Query
+// siblings. It defaults to an allowlist roughly equivalent to bluemonday's
+// UGCPolicy: common inline/formatting tags are kept, everything else
+// (scripts, event handlers, javascript:/data: URIs) is stripped. Applications
+// can replace it with their own HTMLSanitizer, e.g. a bluemonday policy,
+// without this package importing bluemonday itself.
+var Sanitizer HTMLSanitizer = &ugcSanitizer{}
+
+// ugcSanitizer is the default Sanitizer: a small allowlist-based HTML
+// sanitizer covering the tags/attributes typical of user-generated content.
+//
+// Allowed tags: a, abbr, b, blockquote, br, cite, code, dd, dl, dt, em, i,
+// li, ol, p, pre, q, small, strong, sub, sup, u, ul. script and style are
+// dropped along with their text content; every other tag is dropped but its
+// text content is kept. Allowed attributes: title on any allowed tag, href
+// on a, cite on blockquote/q — each URI-valued attribute is further checked
+// by ugcSafeURI and dropped if its scheme isn't http, https or mailto.
+type ugcSanitizer struct{}
+
+var ugcAllowedTags = map[atom.Atom]bool{
+	atom.A: true, atom.Abbr: true, atom.B: true, atom.Blockquote: true, atom.Br: true,
+	atom.Cite: true, atom.Code: true, atom.Dd: true, atom.Dl: true, atom.Dt: true,
+	atom.Em: true, atom.I: true, atom.Li: true, atom.Ol: true, atom.P: true,
+	atom.Pre: true, atom.Q: true, atom.Small: true, atom.Strong: true, atom.Sub: true,
+	atom.Sup: true, atom.U: true, atom.Ul: true,
+}
+
+// ugcDroppedContentTags are stripped along with their text content, unlike
+// other disallowed tags whose text is kept.
+var ugcDroppedContentTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true,
+}
+
+func (s *ugcSanitizer) Sanitize(v string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(v))
+	var out strings.Builder
+	skipDepth := 0
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return out.String()
+		case html.TextToken:
+			if skipDepth == 0 {
+				// tokenizer.Text() decodes entities, so the text is plain
+				// again by this point; it must be re-escaped before being
+				// written back out or encoded markup like "&lt;script&gt;"
+				// would be resurrected into live tags.
+				out.WriteString(template.HTMLEscapeString(string(tokenizer.Text())))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := tokenizer.Token()
+			if ugcDroppedContentTags[tok.DataAtom] {
+				if tok.Type == html.StartTagToken {
+					skipDepth++
+				}
+				continue
+			}
+			if skipDepth == 0 && ugcAllowedTags[tok.DataAtom] {
+				out.WriteString(renderUGCTag(tok))
+			}
+		case html.EndTagToken:
+			tok := tokenizer.Token()
+			if ugcDroppedContentTags[tok.DataAtom] {
+				if skipDepth > 0 {
+					skipDepth--
+				}
+				continue
+			}
+			if skipDepth == 0 && ugcAllowedTags[tok.DataAtom] {
+				out.WriteString("</" + tok.Data + ">")
+			}
+		}
+	}
+}
+
+// renderUGCTag re-serializes an allowed start tag, keeping only the
+// attributes the UGC policy permits for it.
+func renderUGCTag(tok html.Token) string {
+	var sb strings.Builder
+	sb.WriteString("<")
+	sb.WriteString(tok.Data)
+	for _, attr := range tok.Attr {
+		if !ugcAllowedAttr(tok.DataAtom, attr.Key) {
+			continue
+		}
+		if (attr.Key == "href" || attr.Key == "cite") && !ugcSafeURI(attr.Val) {
+			continue
+		}
+		sb.WriteString(" ")
+		sb.WriteString(attr.Key)
+		sb.WriteString(`="`)
+		sb.WriteString(template.HTMLEscapeString(attr.Val))
+		sb.WriteString(`"`)
+	}
+	if tok.Type == html.SelfClosingTagToken {
+		sb.WriteString(" /")
+	}
+	sb.WriteString(">")
+	return sb.String()
+}
+
+func ugcAllowedAttr(tag atom.Atom, key string) bool {
+	switch key {
+	case "title":
+		return true
+	case "href":
+		return tag == atom.A
+	case "cite":
+		return tag == atom.Blockquote || tag == atom.Q
+	default:
+		return false
+	}
+}
+
+// ugcSafeURI rejects javascript:/data:/vbscript: and other dangerous
+// schemes, allowing only http(s), mailto and scheme-relative/relative URIs.
+func ugcSafeURI(v string) bool {
+	v = strings.TrimSpace(v)
+	i := strings.IndexByte(v, ':')
+	if i < 0 {
+		return true
+	}
+	// A real scheme can't contain '/', '?' or '#': a colon that shows up
+	// after one of those belongs to the path/query/fragment of a relative
+	// URI (e.g. "/p?t=10:30"), not a scheme, and must be allowed through.
+	if end := strings.IndexAny(v, "/?#"); end >= 0 && end < i {
+		return true
+	}
+	switch strings.ToLower(v[:i]) {
+	case "http", "https", "mailto":
+		return true
+	default:
+		return false
+	}
+}
+
+// FormValueSafeHTML returns the form field value for the provided name, run
+// through Sanitizer to strip unsafe HTML while keeping common inline markup.
+func (c *Context) FormValueSafeHTML(name string) string {
+	return Sanitizer.Sanitize(c.FormValueTrim(name))
+}
+
+// FormValueEscapedHTML returns the form field value for the provided name,
+// with every HTML-significant character escaped.
+func (c *Context) FormValueEscapedHTML(name string) string {
+	return template.HTMLEscapeString(c.FormValueTrim(name))
+}
+
+// ParamSafeHTML returns path parameter by name, run through Sanitizer to
+// strip unsafe HTML while keeping common inline markup.
+func (c *Context) ParamSafeHTML(name string) string {
+	return Sanitizer.Sanitize(c.ParamTrim(name))
+}
+
+// ParamEscapedHTML returns path parameter by name, with every
+// HTML-significant character escaped.
+func (c *Context) ParamEscapedHTML(name string) string {
+	return template.HTMLEscapeString(c.ParamTrim(name))
+}
+
+// QuerySafeHTML returns the query string value for the provided name, run
+// through Sanitizer to strip unsafe HTML while keeping common inline markup.
+func (c *Context) QuerySafeHTML(name string) string {
+	return Sanitizer.Sanitize(c.QueryTrim(name))
+}
+
+// QueryEscapedHTML returns the query string value for the provided name,
+// with every HTML-significant character escaped.
+func (c *Context) QueryEscapedHTML(name string) string {
+	return template.HTMLEscapeString(c.QueryTrim(name))
+}
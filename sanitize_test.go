@@ -0,0 +1,49 @@
+package context
+
+import "testing"
+
+func TestUGCSanitizerKeepsEncodedMarkupEscaped(t *testing.T) {
+	s := &ugcSanitizer{}
+
+	got := s.Sanitize("&lt;script&gt;alert(1)&lt;/script&gt;")
+	want := "&lt;script&gt;alert(1)&lt;/script&gt;"
+	if got != want {
+		t.Fatalf("Sanitize resurrected encoded markup: got %q, want %q", got, want)
+	}
+}
+
+func TestUGCSanitizerStripsScriptTags(t *testing.T) {
+	s := &ugcSanitizer{}
+
+	got := s.Sanitize("<p>hi</p><script>alert(1)</script>")
+	want := "<p>hi</p>"
+	if got != want {
+		t.Fatalf("Sanitize did not strip script tag: got %q, want %q", got, want)
+	}
+}
+
+func TestUGCSanitizerRejectsJavascriptURI(t *testing.T) {
+	s := &ugcSanitizer{}
+
+	got := s.Sanitize(`<a href="javascript:alert(1)">click</a>`)
+	want := "<a>click</a>"
+	if got != want {
+		t.Fatalf("Sanitize kept unsafe href: got %q, want %q", got, want)
+	}
+}
+
+func TestUGCSanitizerKeepsRelativeHrefWithColon(t *testing.T) {
+	s := &ugcSanitizer{}
+
+	got := s.Sanitize(`<a href="/search?redirect=http://x">go</a>`)
+	want := `<a href="/search?redirect=http://x">go</a>`
+	if got != want {
+		t.Fatalf("Sanitize dropped relative href containing a colon: got %q, want %q", got, want)
+	}
+
+	got = s.Sanitize(`<a href="/p?t=10:30">go</a>`)
+	want = `<a href="/p?t=10:30">go</a>`
+	if got != want {
+		t.Fatalf("Sanitize dropped relative href containing a colon: got %q, want %q", got, want)
+	}
+}